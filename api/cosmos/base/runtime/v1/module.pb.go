@@ -0,0 +1,85 @@
+// Package runtimev1 mirrors cosmos/base/runtime/v1/module.proto.
+//
+// These types are hand-maintained, not protoc-gen-go output: they carry
+// protobuf struct tags so they decode the same way a generated message
+// would, but none of Reset/String/ProtoMessage/ProtoReflect or descriptor
+// bytes are implemented, so a *Module can't be marshaled/unmarshaled as an
+// actual protobuf message (e.g. out of an app config Any) yet. Keep this
+// file's fields in sync with module.proto by hand until the real generator
+// is run over it.
+package runtimev1
+
+// Module is the config object for the runtime module.
+type Module struct {
+	// AppName is the name of the app.
+	AppName string `protobuf:"bytes,1,opt,name=app_name,json=appName,proto3" json:"app_name,omitempty"`
+
+	// BeginBlockers specifies the order of begin blockers. This is only used
+	// if manually specified, otherwise the module manager's default order is
+	// used, which does not follow the order in which modules are added.
+	BeginBlockers []string `protobuf:"bytes,2,rep,name=begin_blockers,json=beginBlockers,proto3" json:"begin_blockers,omitempty"`
+
+	// EndBlockers specifies the order of end blockers. This is only used if
+	// manually specified, otherwise the module manager's default order is
+	// used, which does not follow the order in which modules are added.
+	EndBlockers []string `protobuf:"bytes,3,rep,name=end_blockers,json=endBlockers,proto3" json:"end_blockers,omitempty"`
+
+	// InitGenesis specifies the order of init genesis calls. This is only
+	// used if manually specified, otherwise the module manager's default order
+	// is used, which does not follow the order in which modules are added.
+	InitGenesis []string `protobuf:"bytes,4,rep,name=init_genesis,json=initGenesis,proto3" json:"init_genesis,omitempty"`
+
+	// ExportGenesis specifies the order of export genesis calls. This is
+	// only used if manually specified, otherwise it defaults to the same
+	// order as init_genesis.
+	ExportGenesis []string `protobuf:"bytes,5,rep,name=export_genesis,json=exportGenesis,proto3" json:"export_genesis,omitempty"`
+
+	// OrderMigrations specifies the order of migrations. This is only used
+	// if manually specified, otherwise the module manager's default order is
+	// used, which does not follow the order in which modules are added.
+	OrderMigrations []string `protobuf:"bytes,6,rep,name=order_migrations,json=orderMigrations,proto3" json:"order_migrations,omitempty"`
+
+	// OverrideStoreKeys is an optional list of overrides for the module
+	// store keys to be used in keeper construction.
+	OverrideStoreKeys []*StoreKeyConfig `protobuf:"bytes,7,rep,name=override_store_keys,json=overrideStoreKeys,proto3" json:"override_store_keys,omitempty"`
+
+	// StoreAccessGrants declares the cross-module KVStoreKey access a module
+	// is allowed beyond its own store. A keeper requesting access not present
+	// in this list is rejected by the appBuilder at wiring time.
+	StoreAccessGrants []*StoreAccessGrant `protobuf:"bytes,8,rep,name=store_access_grants,json=storeAccessGrants,proto3" json:"store_access_grants,omitempty"`
+
+	// ExtensionFormatVersions pins the expected snapshot format version for a
+	// named ExtensionSnapshotter. Registering an extension whose
+	// SnapshotFormat doesn't match its pinned entry is rejected, so a format
+	// bump can't silently break state-sync payloads.
+	ExtensionFormatVersions map[string]uint32 `protobuf:"bytes,9,rep,name=extension_format_versions,json=extensionFormatVersions,proto3" json:"extension_format_versions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+
+	// ConsensusEngine selects the runtime/consensus backend used to open the
+	// snapshot metadata DB, e.g. "tendermint" or "cometbft". Defaults to
+	// "tendermint" if unset.
+	ConsensusEngine string `protobuf:"bytes,10,opt,name=consensus_engine,json=consensusEngine,proto3" json:"consensus_engine,omitempty"`
+}
+
+// StoreKeyConfig may be used to override the default module store key, which
+// is the module name.
+type StoreKeyConfig struct {
+	// ModuleName is the name of the module to override the store key of.
+	ModuleName string `protobuf:"bytes,1,opt,name=module_name,json=moduleName,proto3" json:"module_name,omitempty"`
+
+	// KvStoreKey is the kv store key to use instead of the module name.
+	KvStoreKey string `protobuf:"bytes,2,opt,name=kv_store_key,json=kvStoreKey,proto3" json:"kv_store_key,omitempty"`
+}
+
+// StoreAccessGrant declares that RequesterModule may access TargetModule's
+// KVStoreKey, at the given access level.
+type StoreAccessGrant struct {
+	// RequesterModule is the module requesting access to another module's store.
+	RequesterModule string `protobuf:"bytes,1,opt,name=requester_module,json=requesterModule,proto3" json:"requester_module,omitempty"`
+
+	// TargetModule is the module whose store is being accessed.
+	TargetModule string `protobuf:"bytes,2,opt,name=target_module,json=targetModule,proto3" json:"target_module,omitempty"`
+
+	// ReadOnly, if true, restricts the requester to a read-only view of the
+	// target module's store.
+	ReadOnly bool `protobuf:"varint,3,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+}
@@ -0,0 +1,40 @@
+//go:build cometbft
+// +build cometbft
+
+// Package cometbft adapts github.com/cometbft/cometbft-db's DB backend to
+// the runtime/consensus interfaces and registers itself as the "cometbft"
+// consensus engine. It is opt-in behind the "cometbft" build tag.
+package cometbft
+
+import (
+	cometdbm "github.com/cometbft/cometbft-db"
+
+	"github.com/cosmos/cosmos-sdk/baseapp/runtime/consensus"
+)
+
+func init() {
+	consensus.RegisterBackend("cometbft", backend{})
+}
+
+type backend struct{}
+
+func (backend) NewDB(name, backendType, dir string) (consensus.DB, error) {
+	db, err := cometdbm.NewDB(name, cometdbm.BackendType(backendType), dir)
+	if err != nil {
+		return nil, err
+	}
+	return dbWrapper{db}, nil
+}
+
+// dbWrapper adapts a cometbft-db DB to consensus.DB. Every method but
+// NewBatch is promoted unchanged; NewBatch is re-declared because
+// cometdbm.DB.NewBatch returns the named type cometdbm.Batch, which Go does
+// not treat as consensus.Batch even though the two interfaces are
+// structurally identical.
+type dbWrapper struct {
+	cometdbm.DB
+}
+
+func (w dbWrapper) NewBatch() consensus.Batch {
+	return w.DB.NewBatch()
+}
@@ -0,0 +1,44 @@
+//go:build !cometbft
+// +build !cometbft
+
+// Package tendermint adapts github.com/tendermint/tm-db's DB backend to the
+// runtime/consensus interfaces and registers itself as the "tendermint"
+// consensus engine. Its build tag is the inverse of cometbft's: it's the
+// default engine, so it compiles unless "-tags cometbft" is passed, rather
+// than requiring its own opt-in tag. That keeps the engine a genuine
+// link-time choice between the two adapters while still requiring zero
+// build flags for the default case.
+package tendermint
+
+import (
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/cosmos/cosmos-sdk/baseapp/runtime/consensus"
+)
+
+func init() {
+	consensus.RegisterBackend("tendermint", backend{})
+}
+
+type backend struct{}
+
+func (backend) NewDB(name, backendType, dir string) (consensus.DB, error) {
+	db, err := dbm.NewDB(name, dbm.BackendType(backendType), dir)
+	if err != nil {
+		return nil, err
+	}
+	return dbWrapper{db}, nil
+}
+
+// dbWrapper adapts tm-db's dbm.DB to consensus.DB. Every method but
+// NewBatch is promoted unchanged; NewBatch is re-declared because
+// dbm.DB.NewBatch returns the named type dbm.Batch, which Go does not treat
+// as consensus.Batch even though the two interfaces are structurally
+// identical.
+type dbWrapper struct {
+	dbm.DB
+}
+
+func (w dbWrapper) NewBatch() consensus.Batch {
+	return w.DB.NewBatch()
+}
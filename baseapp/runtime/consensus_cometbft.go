@@ -0,0 +1,10 @@
+//go:build cometbft
+// +build cometbft
+
+package runtime
+
+import (
+	// Registers the "cometbft" consensus backend when built with the
+	// "cometbft" tag, so consensus_engine: "cometbft" can be selected.
+	_ "github.com/cosmos/cosmos-sdk/baseapp/runtime/consensus/cometbft"
+)
@@ -0,0 +1,12 @@
+//go:build !cometbft
+// +build !cometbft
+
+package runtime
+
+import (
+	// Registers the "tendermint" consensus backend, runtime's default
+	// engine, so AppCreator.Create can resolve it without the host app
+	// needing its own import. Tagged the same as the adapter package itself
+	// so a "-tags cometbft" build doesn't try to import it.
+	_ "github.com/cosmos/cosmos-sdk/baseapp/runtime/consensus/tendermint"
+)
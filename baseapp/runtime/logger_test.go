@@ -0,0 +1,24 @@
+package runtime
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestTaggedLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewTMLogger(&buf)
+
+	taggedLogger(logger, CallbackBeginBlocker, 42).Info("hello")
+
+	out := buf.String()
+	if !strings.Contains(out, CallbackBeginBlocker) {
+		t.Fatalf("expected output to contain callback tag, got %q", out)
+	}
+	if !strings.Contains(out, "42") {
+		t.Fatalf("expected output to contain block height, got %q", out)
+	}
+}
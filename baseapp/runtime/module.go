@@ -1,16 +1,19 @@
 package runtime
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"path/filepath"
 
 	"github.com/spf13/cast"
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 	dbm "github.com/tendermint/tm-db"
 
 	runtimev1 "github.com/cosmos/cosmos-sdk/api/cosmos/base/runtime/v1"
 	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/baseapp/runtime/consensus"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
@@ -42,9 +45,14 @@ type outputs struct {
 
 type appBuilder struct {
 	storeKeys         []storetypes.StoreKey
+	storeKeysByName   map[string]*storetypes.KVStoreKey
 	interfaceRegistry codectypes.InterfaceRegistry
 	cdc               codec.Codec
 	amino             *codec.LegacyAmino
+	logger            log.Logger
+
+	snapshotExtensions      []snapshottypes.ExtensionSnapshotter
+	extensionFormatVersions map[string]uint32
 }
 
 func (a *appBuilder) registerStoreKey(key storetypes.StoreKey) {
@@ -56,9 +64,12 @@ func init() {
 		coremodule.Provide(
 			provideBuilder,
 			provideApp,
+			provideLogger,
 			provideKVStoreKey,
 			provideTransientStoreKey,
 			provideMemoryStoreKey,
+			provideCrossModuleStoreKey,
+			provideSnapshotExtensionRegistrar,
 		),
 	)
 }
@@ -73,6 +84,7 @@ func provideBuilder() (outputs, error) {
 	amino := codec.NewLegacyAmino()
 	builder := &appBuilder{
 		storeKeys:         nil,
+		storeKeysByName:   make(map[string]*storetypes.KVStoreKey),
 		interfaceRegistry: interfaceRegistry,
 		cdc:               cdc,
 		amino:             amino,
@@ -86,10 +98,19 @@ func provideBuilder() (outputs, error) {
 }
 
 type AppCreator struct {
-	builder *appBuilder
-	modules map[string]module.AppModuleWiringWrapper
-	app     *App
-	config  *runtimev1.Module
+	builder       *appBuilder
+	modules       map[string]module.AppModuleWiringWrapper
+	app           *App
+	config        *runtimev1.Module
+	moduleManager *module.Manager
+}
+
+// ModuleManager returns the module.Manager built by Finish, so a host
+// binary can reach it for operations Finish doesn't perform itself, such as
+// running ExportGenesis or in-place store migrations. It returns nil until
+// Finish has run.
+func (a *AppCreator) ModuleManager() *module.Manager {
+	return a.moduleManager
 }
 
 func (a *AppCreator) RegisterModules(modules ...module.AppModule) error {
@@ -102,7 +123,22 @@ func (a *AppCreator) RegisterModules(modules ...module.AppModule) error {
 	return nil
 }
 
+// snapshotDBAdapter adapts the consensus.DB returned by the selected
+// consensus backend to tm-db's dbm.DB, which snapshots.NewStore still
+// requires until baseapp itself is ported off tm-db. consensus.DB and
+// dbm.DB are structurally identical except for NewBatch's return type, so
+// only NewBatch needs to be re-declared here.
+type snapshotDBAdapter struct {
+	consensus.DB
+}
+
+func (a snapshotDBAdapter) NewBatch() dbm.Batch {
+	return a.DB.NewBatch()
+}
+
 func (a *AppCreator) Create(logger log.Logger, db dbm.DB, traceStore io.Writer, appOpts servertypes.AppOptions, baseAppOptions ...func(*baseapp.BaseApp)) *App {
+	a.builder.logger = logger
+
 	var cache sdk.MultiStorePersistentCache
 
 	if cast.ToBool(appOpts.Get(server.FlagInterBlockCache)) {
@@ -119,12 +155,21 @@ func (a *AppCreator) Create(logger log.Logger, db dbm.DB, traceStore io.Writer,
 		panic(err)
 	}
 
+	engineName := a.config.ConsensusEngine
+	if engineName == "" {
+		engineName = "tendermint"
+	}
+	engine, err := consensus.GetBackend(engineName)
+	if err != nil {
+		panic(err)
+	}
+
 	snapshotDir := filepath.Join(cast.ToString(appOpts.Get(flags.FlagHome)), "data", "snapshots")
-	snapshotDB, err := dbm.NewDB("metadata", server.GetAppDBBackend(appOpts), snapshotDir)
+	snapshotConsensusDB, err := engine.NewDB("metadata", string(server.GetAppDBBackend(appOpts)), snapshotDir)
 	if err != nil {
 		panic(err)
 	}
-	snapshotStore, err := snapshots.NewStore(snapshotDB, snapshotDir)
+	snapshotStore, err := snapshots.NewStore(snapshotDBAdapter{snapshotConsensusDB}, snapshotDir)
 	if err != nil {
 		panic(err)
 	}
@@ -162,23 +207,88 @@ func (a *AppCreator) Finish(loadLatest bool) error {
 		return fmt.Errorf("app not created yet, can't finish")
 	}
 
-	for _, blocker := range a.config.BeginBlockers {
-		mod, ok := a.modules[blocker]
-		if !ok {
-			return fmt.Errorf("can't find module named %q registered as a begin blocker", blocker)
-		}
+	appModules := make(map[string]module.AppModule, len(a.modules))
+	for name, wrapper := range a.modules {
+		appModules[name] = wrapper.AppModule
+	}
+	mm := module.NewManagerFromMap(appModules)
+
+	if err := setModuleOrder(mm.SetOrderBeginBlockers, a.modules, a.config.BeginBlockers, "begin blocker"); err != nil {
+		return err
+	}
+	if err := setModuleOrder(mm.SetOrderEndBlockers, a.modules, a.config.EndBlockers, "end blocker"); err != nil {
+		return err
+	}
+	if err := setModuleOrder(mm.SetOrderInitGenesis, a.modules, a.config.InitGenesis, "init genesis"); err != nil {
+		return err
+	}
+	exportGenesis := a.config.ExportGenesis
+	if len(exportGenesis) == 0 {
+		exportGenesis = a.config.InitGenesis
+	}
+	if err := setModuleOrder(mm.SetOrderExportGenesis, a.modules, exportGenesis, "export genesis"); err != nil {
+		return err
+	}
+	if err := setModuleOrder(mm.SetOrderMigrations, a.modules, a.config.OrderMigrations, "migration"); err != nil {
+		return err
+	}
 
+	bApp := a.app.BaseApp
+	cfg := module.NewConfigurator(a.builder.cdc, bApp.MsgServiceRouter(), bApp.GRPCQueryRouter())
+	if err := mm.RegisterServices(cfg); err != nil {
+		return err
 	}
 
+	bApp.SetBeginBlocker(func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+		ctx = ctx.WithLogger(taggedLogger(a.builder.logger, CallbackBeginBlocker, ctx.BlockHeight()))
+		return mm.BeginBlock(ctx, req)
+	})
+	bApp.SetEndBlocker(func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+		ctx = ctx.WithLogger(taggedLogger(a.builder.logger, CallbackEndBlocker, ctx.BlockHeight()))
+		return mm.EndBlock(ctx, req)
+	})
+	bApp.SetInitChainer(func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+		var genesisState map[string]json.RawMessage
+		if err := json.Unmarshal(req.AppStateBytes, &genesisState); err != nil {
+			panic(err)
+		}
+		return mm.InitGenesis(ctx, a.builder.cdc, genesisState)
+	})
+
+	a.moduleManager = mm
+
 	if loadLatest {
 		if err := a.app.LoadLatestVersion(); err != nil {
 			return err
 		}
+
+		if len(a.builder.snapshotExtensions) > 0 {
+			if err := bApp.SnapshotManager().RegisterExtensions(a.builder.snapshotExtensions...); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
+// setModuleOrder validates that every name in order is a registered module
+// and, if so, applies it via set. An empty order leaves the manager's
+// default ordering untouched; since the manager is built from a map, that
+// default does not follow the order in which modules were registered.
+func setModuleOrder(set func(...string), modules map[string]module.AppModuleWiringWrapper, order []string, kind string) error {
+	if len(order) == 0 {
+		return nil
+	}
+	for _, name := range order {
+		if _, ok := modules[name]; !ok {
+			return fmt.Errorf("can't find module named %q registered as a %s", name, kind)
+		}
+	}
+	set(order...)
+	return nil
+}
+
 func provideApp(config *runtimev1.Module, builder *appBuilder, modules map[string]module.AppModuleWiringWrapper) *AppCreator {
 	return &AppCreator{
 		config:  config,
@@ -187,10 +297,9 @@ func provideApp(config *runtimev1.Module, builder *appBuilder, modules map[strin
 	}
 }
 
-func provideKVStoreKey(key container.ModuleKey, builder *appBuilder) *storetypes.KVStoreKey {
-	storeKey := storetypes.NewKVStoreKey(key.Name())
-	builder.registerStoreKey(storeKey)
-	return storeKey
+func provideKVStoreKey(key container.ModuleKey, config *runtimev1.Module, builder *appBuilder) *storetypes.KVStoreKey {
+	storeName := resolveStoreKeyName(key.Name(), config.OverrideStoreKeys)
+	return getOrCreateStoreKey(storeName, builder)
 }
 
 func provideTransientStoreKey(key container.ModuleKey, builder *appBuilder) *storetypes.TransientStoreKey {
@@ -203,4 +312,4 @@ func provideMemoryStoreKey(key container.ModuleKey, builder *appBuilder) *storet
 	storeKey := storetypes.NewMemoryStoreKey(key.Name())
 	builder.registerStoreKey(storeKey)
 	return storeKey
-}
\ No newline at end of file
+}
@@ -0,0 +1,37 @@
+package runtime
+
+import (
+	"fmt"
+
+	runtimev1 "github.com/cosmos/cosmos-sdk/api/cosmos/base/runtime/v1"
+	snapshottypes "github.com/cosmos/cosmos-sdk/snapshots/types"
+)
+
+// SnapshotExtensionRegistrar is injected into modules so they can register a
+// snapshottypes.ExtensionSnapshotter with the app's snapshot manager.
+// Extensions collected here are applied to the BaseApp's SnapshotManager by
+// Finish, once the multistore has been loaded.
+type SnapshotExtensionRegistrar struct {
+	builder *appBuilder
+}
+
+// RegisterExtension registers snapshotter under name. name must match
+// snapshotter.SnapshotName(), and if runtimev1.Module pins an expected
+// format for name, snapshotter.SnapshotFormat() must match it.
+func (r *SnapshotExtensionRegistrar) RegisterExtension(name string, snapshotter snapshottypes.ExtensionSnapshotter) error {
+	if name != snapshotter.SnapshotName() {
+		return fmt.Errorf("extension registered as %q but reports SnapshotName() %q", name, snapshotter.SnapshotName())
+	}
+
+	if expected, ok := r.builder.extensionFormatVersions[name]; ok && expected != snapshotter.SnapshotFormat() {
+		return fmt.Errorf("extension %q has snapshot format %d, expected %d per runtimev1.Module config", name, snapshotter.SnapshotFormat(), expected)
+	}
+
+	r.builder.snapshotExtensions = append(r.builder.snapshotExtensions, snapshotter)
+	return nil
+}
+
+func provideSnapshotExtensionRegistrar(config *runtimev1.Module, builder *appBuilder) *SnapshotExtensionRegistrar {
+	builder.extensionFormatVersions = config.ExtensionFormatVersions
+	return &SnapshotExtensionRegistrar{builder: builder}
+}
@@ -0,0 +1,56 @@
+package runtime
+
+import (
+	"testing"
+
+	runtimev1 "github.com/cosmos/cosmos-sdk/api/cosmos/base/runtime/v1"
+	snapshottypes "github.com/cosmos/cosmos-sdk/snapshots/types"
+)
+
+type fakeExtensionSnapshotter struct {
+	name   string
+	format uint32
+}
+
+func (f fakeExtensionSnapshotter) SnapshotName() string      { return f.name }
+func (f fakeExtensionSnapshotter) SnapshotFormat() uint32    { return f.format }
+func (f fakeExtensionSnapshotter) SupportedFormats() []uint32 { return []uint32{f.format} }
+
+func (f fakeExtensionSnapshotter) SnapshotExtension(height uint64, payloadWriter snapshottypes.ExtensionPayloadWriter) error {
+	return nil
+}
+
+func (f fakeExtensionSnapshotter) RestoreExtension(height uint64, format uint32, payloadReader snapshottypes.ExtensionPayloadReader) error {
+	return nil
+}
+
+func newTestRegistrar(formatVersions map[string]uint32) *SnapshotExtensionRegistrar {
+	builder := &appBuilder{}
+	return provideSnapshotExtensionRegistrar(&runtimev1.Module{ExtensionFormatVersions: formatVersions}, builder)
+}
+
+func TestRegisterExtensionNameMismatch(t *testing.T) {
+	r := newTestRegistrar(nil)
+	err := r.RegisterExtension("wasm", fakeExtensionSnapshotter{name: "other", format: 1})
+	if err == nil {
+		t.Fatal("expected an error for mismatched extension name")
+	}
+}
+
+func TestRegisterExtensionFormatMismatch(t *testing.T) {
+	r := newTestRegistrar(map[string]uint32{"wasm": 2})
+	err := r.RegisterExtension("wasm", fakeExtensionSnapshotter{name: "wasm", format: 1})
+	if err == nil {
+		t.Fatal("expected an error for mismatched snapshot format")
+	}
+}
+
+func TestRegisterExtensionSuccess(t *testing.T) {
+	r := newTestRegistrar(map[string]uint32{"wasm": 1})
+	if err := r.RegisterExtension("wasm", fakeExtensionSnapshotter{name: "wasm", format: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(r.builder.snapshotExtensions) != 1 {
+		t.Fatalf("expected 1 registered extension, got %d", len(r.builder.snapshotExtensions))
+	}
+}
@@ -0,0 +1,131 @@
+package runtime
+
+import (
+	"fmt"
+	"io"
+
+	runtimev1 "github.com/cosmos/cosmos-sdk/api/cosmos/base/runtime/v1"
+	"github.com/cosmos/cosmos-sdk/container"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// StoreKeyFor is the container input type a keeper embeds to request access
+// to another module's KVStoreKey. Target names the module whose store is
+// being requested; ReadOnly must be false to get write access, and is only
+// satisfied by a StoreAccessGrant that itself isn't read-only.
+type StoreKeyFor struct {
+	container.In
+
+	Target   string
+	ReadOnly bool
+}
+
+// resolveStoreKeyName returns the store key name a module should use,
+// honoring any override declared for it in overrides.
+func resolveStoreKeyName(moduleName string, overrides []*runtimev1.StoreKeyConfig) string {
+	for _, override := range overrides {
+		if override.ModuleName == moduleName {
+			return override.KvStoreKey
+		}
+	}
+	return moduleName
+}
+
+// getOrCreateStoreKey returns the already-registered KVStoreKey named
+// storeName, creating and registering one if this is the first request for
+// it. Both a module's own provideKVStoreKey and a cross-module
+// provideCrossModuleStoreKey call this, so whichever one the container
+// resolves first wins, and the other reuses the same key — a cross-module
+// request never fails just because the target module's own key provider
+// happened to run later.
+func getOrCreateStoreKey(storeName string, builder *appBuilder) *storetypes.KVStoreKey {
+	if storeKey, ok := builder.storeKeysByName[storeName]; ok {
+		return storeKey
+	}
+
+	storeKey := storetypes.NewKVStoreKey(storeName)
+	builder.registerStoreKey(storeKey)
+	builder.storeKeysByName[storeName] = storeKey
+	return storeKey
+}
+
+// hasStoreAccessGrant reports whether requester is allowed readOnly (or
+// read-write, if readOnly is false) access to target's store according to
+// grants. A read-only grant only satisfies a read-only request.
+func hasStoreAccessGrant(requester, target string, readOnly bool, grants []*runtimev1.StoreAccessGrant) bool {
+	for _, grant := range grants {
+		if grant.RequesterModule != requester || grant.TargetModule != target {
+			continue
+		}
+		return readOnly || !grant.ReadOnly
+	}
+	return false
+}
+
+// CrossModuleStore is what a keeper receives when it requests access to
+// another module's store via StoreKeyFor. The underlying KVStoreKey isn't
+// exposed directly: possessing a *storetypes.KVStoreKey is enough to get
+// full read-write access to it regardless of any access-control flag, so
+// the read-only guarantee has to be enforced on the store returned at the
+// point of use instead.
+type CrossModuleStore struct {
+	key      *storetypes.KVStoreKey
+	readOnly bool
+}
+
+// Load resolves the store against ctx. When the grant backing this
+// CrossModuleStore is read-only, Set and Delete on the returned store panic.
+func (s CrossModuleStore) Load(ctx sdk.Context) sdk.KVStore {
+	store := ctx.KVStore(s.key)
+	if s.readOnly {
+		return readOnlyKVStore{store}
+	}
+	return store
+}
+
+// readOnlyKVStore wraps an sdk.KVStore and rejects writes, so a module
+// granted read-only cross-module store access can't mutate it even though
+// it shares the same underlying store as the target module's keeper.
+type readOnlyKVStore struct {
+	sdk.KVStore
+}
+
+func (readOnlyKVStore) Set(key, value []byte) {
+	panic("read-only cross-module store access: Set is not permitted")
+}
+
+func (readOnlyKVStore) Delete(key []byte) {
+	panic("read-only cross-module store access: Delete is not permitted")
+}
+
+// CacheWrap and CacheWrapWithTrace also have to be blocked: the cache wrap
+// they'd otherwise return is, in the real store implementation, the same
+// concrete type as the underlying store itself, which still has working
+// Set/Delete methods and a Write() that flushes straight through. Without
+// this, a caller could bypass the read-only guard entirely via
+// store.CacheWrap().(sdk.KVStore).Set(...); cacheWrap.Write().
+func (readOnlyKVStore) CacheWrap() storetypes.CacheWrap {
+	panic("read-only cross-module store access: CacheWrap is not permitted")
+}
+
+func (readOnlyKVStore) CacheWrapWithTrace(w io.Writer, tc storetypes.TraceContext) storetypes.CacheWrap {
+	panic("read-only cross-module store access: CacheWrapWithTrace is not permitted")
+}
+
+// provideCrossModuleStoreKey resolves a StoreKeyFor request into a
+// CrossModuleStore bound to the target module's KVStoreKey. It calls
+// getOrCreateStoreKey rather than reading builder.storeKeysByName directly,
+// so a correctly-granted request can't fail just because the container
+// happened to resolve it before the target module's own provideKVStoreKey.
+// It panics if the requesting module hasn't been granted access via
+// runtimev1.Module's StoreAccessGrants.
+func provideCrossModuleStoreKey(in StoreKeyFor, key container.ModuleKey, config *runtimev1.Module, builder *appBuilder) CrossModuleStore {
+	if !hasStoreAccessGrant(key.Name(), in.Target, in.ReadOnly, config.StoreAccessGrants) {
+		panic(fmt.Sprintf("module %q requested undeclared or insufficient store access to module %q; add a StoreAccessGrant to runtimev1.Module", key.Name(), in.Target))
+	}
+
+	storeName := resolveStoreKeyName(in.Target, config.OverrideStoreKeys)
+	storeKey := getOrCreateStoreKey(storeName, builder)
+	return CrossModuleStore{key: storeKey, readOnly: in.ReadOnly}
+}
@@ -0,0 +1,36 @@
+package runtime
+
+import (
+	"testing"
+
+	runtimev1 "github.com/cosmos/cosmos-sdk/api/cosmos/base/runtime/v1"
+)
+
+func TestResolveStoreKeyName(t *testing.T) {
+	overrides := []*runtimev1.StoreKeyConfig{
+		{ModuleName: "service", KvStoreKey: "instance"},
+	}
+
+	if got := resolveStoreKeyName("service", overrides); got != "instance" {
+		t.Fatalf("expected overridden name %q, got %q", "instance", got)
+	}
+	if got := resolveStoreKeyName("bank", overrides); got != "bank" {
+		t.Fatalf("expected unoverridden name %q, got %q", "bank", got)
+	}
+}
+
+func TestHasStoreAccessGrant(t *testing.T) {
+	grants := []*runtimev1.StoreAccessGrant{
+		{RequesterModule: "gov", TargetModule: "bank", ReadOnly: true},
+	}
+
+	if !hasStoreAccessGrant("gov", "bank", true, grants) {
+		t.Fatal("expected read-only request to be granted")
+	}
+	if hasStoreAccessGrant("gov", "bank", false, grants) {
+		t.Fatal("expected read-write request to be denied by a read-only grant")
+	}
+	if hasStoreAccessGrant("staking", "bank", true, grants) {
+		t.Fatal("expected request from an ungranted module to be denied")
+	}
+}
@@ -0,0 +1,16 @@
+package types
+
+import (
+	"context"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// UnwrapSDKContext unwraps ctx into a Context and its logger. Prefer this
+// over ctx.Logger() directly: the runtime's BeginBlocker/EndBlocker wiring
+// tags the Context's logger with the callback name and block height, so
+// callers get that context for free.
+func UnwrapSDKContext(ctx context.Context) (Context, log.Logger) {
+	sdkCtx := ctx.(Context)
+	return sdkCtx, sdkCtx.Logger()
+}